@@ -0,0 +1,113 @@
+// Command cclint lints Go source files from the command line.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+
+	"github.com/zm-stack/CCLint/linter"
+	"github.com/zm-stack/CCLint/linter/cli"
+	"github.com/zm-stack/CCLint/linter/lsp"
+)
+
+func main() {
+	// "cclint lsp" runs the Language Server Protocol server over
+	// stdin/stdout instead of a one-shot lint pass; it takes no flags of
+	// its own since an LSP client configures everything through the
+	// initialize request, not argv.
+	if len(os.Args) > 1 && os.Args[1] == "lsp" {
+		if err := lsp.Serve(os.Stdin, os.Stdout, nil, nil); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fix := flag.Bool("fix", false, "apply fixes in place instead of just reporting them")
+	format := flag.String("format", "text", "output format: text, json, sarif or checkstyle")
+	timeout := flag.Duration("timeout", 0, "per-file lint timeout, e.g. 30s (0 means no timeout)")
+	workers := flag.Int("workers", 0, "number of files to lint concurrently (0 means GOMAXPROCS)")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "usage: cclint [flags] <file-or-dir>...\n       cclint lsp")
+		os.Exit(2)
+	}
+
+	files, err := loadFiles(flag.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	opts := cli.Options{
+		Fix:     *fix,
+		Format:  *format,
+		Timeout: *timeout,
+		Workers: *workers,
+	}
+	if err := cli.Run(os.Stdout, files, opts); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// loadFiles parses every .go file found by walking paths into linter.Files,
+// grouping files from the same directory into one linter.Package so that
+// Package.Check later sees a whole package at once rather than one file at
+// a time.
+func loadFiles(paths []string) ([]*linter.File, error) {
+	fset := token.NewFileSet()
+	pkgs := map[string]*linter.Package{}
+	var files []*linter.File
+
+	var walk func(path string) error
+	walk = func(path string) error {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			entries, err := os.ReadDir(path)
+			if err != nil {
+				return err
+			}
+			for _, entry := range entries {
+				if err := walk(filepath.Join(path, entry.Name())); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".go" {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		dir := filepath.Dir(path)
+		pkg, ok := pkgs[dir]
+		if !ok {
+			pkg = linter.NewPackage(fset)
+			pkgs[dir] = pkg
+		}
+		f, err := pkg.AddFile(path, content)
+		if err != nil {
+			return err
+		}
+		files = append(files, f)
+		return nil
+	}
+
+	for _, path := range paths {
+		if err := walk(path); err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,108 @@
+// Package cli ties a linter.Runner to an output format. It lives apart
+// from package linter itself so that format's SARIF/JSON/Checkstyle
+// encoders - which import linter for the Failure type - don't create an
+// import cycle with the runner that selects them.
+package cli
+
+import (
+	"go/importer"
+	"io"
+	"time"
+
+	"github.com/zm-stack/CCLint/linter"
+	"github.com/zm-stack/CCLint/linter/format"
+)
+
+// Options configures a lint pass end to end.
+type Options struct {
+	Rules  []linter.Rule
+	Config linter.RulesConfig
+
+	// Fix is passed straight through to linter.Runner.
+	Fix bool
+
+	// Format selects the output encoding: "text" (default), "json",
+	// "sarif" or "checkstyle".
+	Format string
+
+	// Workers and Timeout are passed straight through to linter.Runner.
+	Workers int
+	Timeout time.Duration
+}
+
+// Run lints every file in files with opts and writes the formatted result
+// to w. Files are linted concurrently by linter.Runner and collected in a
+// stable order before formatting, so formatters which emit a single
+// document (SARIF, Checkstyle) see a reproducible whole-run result rather
+// than whatever order workers happened to finish in.
+func Run(w io.Writer, files []*linter.File, opts Options) error {
+	name := opts.Format
+	if name == "" {
+		name = "text"
+	}
+	formatter, err := format.ByName(name)
+	if err != nil {
+		return err
+	}
+
+	checkPackages(files)
+
+	runner := &linter.Runner{
+		Rules:   opts.Rules,
+		Config:  opts.Config,
+		Fix:     opts.Fix,
+		Workers: opts.Workers,
+		Timeout: opts.Timeout,
+	}
+	failures := runner.Lint(files)
+
+	// SARIF is the one formatter that reports suppressions, so it alone
+	// needs the failures File.lint would otherwise have already dropped,
+	// plus the revive:enable|disable intervals that dropped them.
+	if sarif, ok := formatter.(format.SARIF); ok {
+		sarif.Suppressed = disabledIntervals(files, opts.Rules)
+		failures = append(failures, suppressedFailures(files, opts)...)
+		formatter = sarif
+	}
+
+	return formatter.Format(w, failures)
+}
+
+// suppressedFailures re-lints every file to recover the failures that
+// Runner.Lint's underlying File.Lint call already filtered out as
+// suppressed, so SARIF can report them alongside the intervals that
+// suppressed them instead of just omitting them.
+func suppressedFailures(files []*linter.File, opts Options) []linter.Failure {
+	var out []linter.Failure
+	for _, f := range files {
+		_, suppressed := f.LintWithSuppressed(opts.Rules, opts.Config)
+		out = append(out, suppressed...)
+	}
+	return out
+}
+
+func disabledIntervals(files []*linter.File, rules []linter.Rule) []linter.DisabledInterval {
+	var out []linter.DisabledInterval
+	for _, f := range files {
+		out = append(out, f.DisabledIntervals(rules)...)
+	}
+	return out
+}
+
+// checkPackages type-checks every distinct linter.Package referenced by
+// files via Package.Check, so that by the time Runner.Lint runs, rules
+// can rely on File.TypeInfo/TypeOf/ObjectOf instead of falling back to
+// the slower per-expression evaluation. Type errors are intentionally
+// not fatal here - see Package.Check - since a file that doesn't fully
+// type-check is still worth linting with whatever information resolved.
+func checkPackages(files []*linter.File) {
+	imp := importer.Default()
+	seen := make(map[*linter.Package]bool, len(files))
+	for _, f := range files {
+		if f.Pkg == nil || seen[f.Pkg] {
+			continue
+		}
+		seen[f.Pkg] = true
+		f.Pkg.Check(f.AST.Name.Name, imp)
+	}
+}
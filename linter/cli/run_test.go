@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/ast"
+	"go/token"
+	"testing"
+
+	"github.com/zm-stack/CCLint/linter"
+)
+
+// alwaysFailRule reports one failure on every *ast.File it sees.
+type alwaysFailRule struct{}
+
+func (alwaysFailRule) Name() string { return "always-fail" }
+
+func (alwaysFailRule) Apply(f *linter.File, _ linter.RuleConfig) []linter.Failure {
+	return []linter.Failure{{Failure: "always fails", Node: f.AST.Decls[0]}}
+}
+
+const suppressedSrc = `package p
+
+// revive:disable:always-fail
+var x = 1
+
+// revive:enable:always-fail
+`
+
+func TestRunSARIFReportsSuppressedFailures(t *testing.T) {
+	pkg := linter.NewPackage(token.NewFileSet())
+	f, err := pkg.AddFile("f.go", []byte(suppressedSrc))
+	if err != nil {
+		t.Fatalf("AddFile: %v", err)
+	}
+
+	var buf bytes.Buffer
+	opts := Options{Rules: []linter.Rule{alwaysFailRule{}}, Format: "sarif"}
+	if err := Run(&buf, []*linter.File{f}, opts); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	var log struct {
+		Runs []struct {
+			Results []struct {
+				Suppressions []struct {
+					Kind string `json:"kind"`
+				} `json:"suppressions,omitempty"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("unmarshal SARIF output: %v\n%s", err, buf.String())
+	}
+
+	results := log.Runs[0].Results
+	if len(results) != 1 {
+		t.Fatalf("got %d SARIF results, want 1 (the suppressed failure, recovered for SARIF only)", len(results))
+	}
+	if len(results[0].Suppressions) != 1 {
+		t.Fatalf("Suppressions = %#v, want one entry", results[0].Suppressions)
+	}
+}
+
+func TestRunTextFormatDropsSuppressedFailures(t *testing.T) {
+	pkg := linter.NewPackage(token.NewFileSet())
+	f, err := pkg.AddFile("f.go", []byte(suppressedSrc))
+	if err != nil {
+		t.Fatalf("AddFile: %v", err)
+	}
+
+	var buf bytes.Buffer
+	opts := Options{Rules: []linter.Rule{alwaysFailRule{}}}
+	if err := Run(&buf, []*linter.File{f}, opts); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("text output = %q, want empty since the only failure is suppressed", buf.String())
+	}
+}
+
+func TestRunWiresPackageCheck(t *testing.T) {
+	pkg := linter.NewPackage(token.NewFileSet())
+	f, err := pkg.AddFile("f.go", []byte("package p\n\nvar x = 1\n"))
+	if err != nil {
+		t.Fatalf("AddFile: %v", err)
+	}
+
+	if err := Run(&bytes.Buffer{}, []*linter.File{f}, Options{Format: "text"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	ident := f.AST.Decls[0].(*ast.GenDecl).Specs[0].(*ast.ValueSpec).Names[0]
+	if f.TypeOf(f.AST.Decls[0].(*ast.GenDecl).Specs[0].(*ast.ValueSpec).Values[0]) == nil {
+		t.Error("TypeOf(x's value) = nil, want Run to have called Package.Check so type info is populated")
+	}
+	if f.ObjectOf(ident) == nil {
+		t.Error("ObjectOf(x) = nil, want Run to have called Package.Check so type info is populated")
+	}
+}
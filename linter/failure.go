@@ -0,0 +1,61 @@
+package linter
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// FailurePosition points to a start and end location in a file.
+type FailurePosition struct {
+	Start token.Position
+	End   token.Position
+}
+
+// Failure represents the result of a failed rule check.
+type Failure struct {
+	Failure     string
+	RuleName    string
+	Category    string
+	Position    FailurePosition
+	Node        ast.Node `json:"-"`
+	Replacement []Replacement
+}
+
+// Replacement is a single text edit a rule proposes to fix a Failure.
+// Pos and End delimit the span being replaced; NewText is substituted in
+// its place. Replacements within one file must be disjoint: File.ApplyFixes
+// returns an error, and --fix leaves the file unchanged, as soon as two
+// replacements overlap.
+type Replacement struct {
+	Pos     token.Pos
+	End     token.Pos
+	NewText string
+}
+
+// Fixable is implemented by rules that can produce Replacements for (some
+// of) the Failures they report. A rule may still return Failures without
+// Replacement set even if it implements Fixable; those are reported as
+// usual but are not touched by --fix.
+type Fixable interface {
+	// CanFix reports whether failure can be automatically fixed by this
+	// rule. Rules that always populate Failure.Replacement can simply
+	// return true unconditionally.
+	CanFix(failure Failure) bool
+}
+
+// DisabledInterval represents a range of lines for which a rule is disabled.
+type DisabledInterval struct {
+	RuleName string
+	From     token.Position
+	To       token.Position
+}
+
+type disabledIntervalsMap map[string][]DisabledInterval
+
+// ToFailurePosition returns the FailurePosition for the given node bounds.
+func ToFailurePosition(start, end token.Pos, f *File) FailurePosition {
+	return FailurePosition{
+		Start: f.ToPosition(start),
+		End:   f.ToPosition(end),
+	}
+}
@@ -2,6 +2,7 @@ package linter
 
 import (
 	"bytes"
+	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/printer"
@@ -9,6 +10,7 @@ import (
 	"go/types"
 	"math"
 	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -18,6 +20,13 @@ type File struct {
 	Pkg     *Package
 	content []byte
 	AST     *ast.File
+
+	// TypeInfo holds the result of type-checking the whole package this
+	// file belongs to, populated once by Package.Check rather than
+	// per-expression by each rule. It is nil until Check has run; until
+	// then TypeOf and ObjectOf return nil, and IsUntypedConst falls back
+	// to re-evaluating the expression on its own.
+	TypeInfo *types.Info
 }
 
 // NewFile creates a new file
@@ -48,6 +57,48 @@ func (f *File) Render(x interface{}) string {
 	return buf.String()
 }
 
+// ApplyFixes rewrites f's content by applying every Replacement attached to
+// failures, in position order. It fails if two replacements overlap, and
+// re-parses the result to make sure the rewrite is still valid Go before
+// returning it; either way f.content is left untouched, so callers can
+// retry or discard without side effects. On success it returns the new
+// file content for the caller to persist.
+func (f *File) ApplyFixes(failures []Failure) ([]byte, error) {
+	var edits []Replacement
+	for _, failure := range failures {
+		edits = append(edits, failure.Replacement...)
+	}
+	if len(edits) == 0 {
+		return f.content, nil
+	}
+
+	sort.Slice(edits, func(i, j int) bool { return edits[i].Pos < edits[j].Pos })
+	for i := 1; i < len(edits); i++ {
+		if edits[i].Pos < edits[i-1].End {
+			return nil, fmt.Errorf("%s: overlapping fixes at %s and %s",
+				f.Name, f.ToPosition(edits[i-1].Pos), f.ToPosition(edits[i].Pos))
+		}
+	}
+
+	var buf bytes.Buffer
+	offset := 0
+	base := f.Pkg.fset.File(f.AST.Pos()).Base()
+	for _, edit := range edits {
+		start := int(edit.Pos) - base
+		end := int(edit.End) - base
+		buf.Write(f.content[offset:start])
+		buf.WriteString(edit.NewText)
+		offset = end
+	}
+	buf.Write(f.content[offset:])
+	fixed := buf.Bytes()
+
+	if _, err := parser.ParseFile(token.NewFileSet(), f.Name, fixed, parser.ParseComments); err != nil {
+		return nil, fmt.Errorf("%s: fix produced invalid Go: %w", f.Name, err)
+	}
+	return fixed, nil
+}
+
 var basicTypeKinds = map[types.BasicKind]string{
 	types.UntypedBool:    "bool",
 	types.UntypedInt:     "int",
@@ -57,12 +108,57 @@ var basicTypeKinds = map[types.BasicKind]string{
 	types.UntypedString:  "string",
 }
 
+// TypeOf returns the type go/types assigned to expr, or nil if the
+// package hasn't been type-checked via Package.Check or expr wasn't part
+// of it (e.g. it was synthesized by a rule rather than parsed).
+func (f *File) TypeOf(expr ast.Expr) types.Type {
+	if f.TypeInfo == nil {
+		return nil
+	}
+	return f.TypeInfo.Types[expr].Type
+}
+
+// ObjectOf returns the object id refers to: its declaration if id is
+// itself a declaring identifier, otherwise the declaration it resolves
+// to. It returns nil under the same conditions as TypeOf.
+func (f *File) ObjectOf(id *ast.Ident) types.Object {
+	if f.TypeInfo == nil {
+		return nil
+	}
+	if obj := f.TypeInfo.Defs[id]; obj != nil {
+		return obj
+	}
+	return f.TypeInfo.Uses[id]
+}
+
 // IsUntypedConst reports whether expr is an untyped constant,
 // and indicates what its default type is.
 // scope may be nil.
 func (f *File) IsUntypedConst(expr ast.Expr) (defType string, ok bool) {
-	// Re-evaluate expr outside of its context to see if it's untyped.
-	// (An expr evaluated within, for example, an assignment context will get the type of the LHS.)
+	if f.TypeInfo != nil {
+		// Info.Types records the type expr has in its context, so a
+		// constant that its context converts (the 1 in `var x = 1` or in
+		// `var y int64 = 1`) has a typed type here and is reported as not
+		// untyped; only a constant that stays untyped (such as the value
+		// in `const c = 1`) is reported as untyped.
+		tv, ok := f.TypeInfo.Types[expr]
+		if !ok || tv.Value == nil {
+			return "", false
+		}
+		if b, ok := tv.Type.(*types.Basic); ok {
+			if dt, ok := basicTypeKinds[b.Kind()]; ok {
+				return dt, true
+			}
+		}
+		return "", false
+	}
+
+	// No TypeInfo yet (Package.Check was never called for this file):
+	// fall back to re-evaluating expr outside of its context to see if
+	// it's untyped. (An expr evaluated within, for example, an assignment
+	// context will get the type of the LHS.) This is slower, and only
+	// recovers the default basic kind, but keeps rules working against a
+	// File whose package hasn't been type-checked.
 	exprStr := f.Render(expr)
 	tv, err := types.Eval(f.Pkg.fset, f.Pkg.TypesPkg, expr.Pos(), exprStr)
 	if err != nil {
@@ -84,20 +180,51 @@ func (f *File) isMain() bool {
 	return false
 }
 
-func (f *File) lint(rules []Rule, rulesConfig RulesConfig, failures chan Failure) {
+// Lint runs rules against f and returns the resulting failures synchronously.
+// It is a thin wrapper around lint for callers, such as the LSP server, that
+// need the failures for a single file rather than a streamed batch.
+func (f *File) Lint(rules []Rule, rulesConfig RulesConfig) []Failure {
+	failures := make(chan Failure, 64)
+	done := make(chan struct{})
+	var result []Failure
+	go func() {
+		for failure := range failures {
+			result = append(result, failure)
+		}
+		close(done)
+	}()
+	f.lint(rules, rulesConfig, failures)
+	close(failures)
+	<-done
+	return result
+}
+
+// LintWithSuppressed is like Lint, but also returns the failures that
+// were produced and then dropped because they fell inside a
+// revive:enable|disable interval, instead of discarding them outright.
+// Formatters that want to report suppressions explicitly (SARIF, for
+// GitHub code scanning and similar platforms) use this instead of Lint.
+func (f *File) LintWithSuppressed(rules []Rule, rulesConfig RulesConfig) (included, suppressed []Failure) {
 	disabledIntervals := f.disabledIntervals(rules)
 	for _, currentRule := range rules {
 		config := rulesConfig[currentRule.Name()]
-		currentFailures := currentRule.Apply(f, config)
-		for idx, failure := range currentFailures {
-			if failure.RuleName == "" {
-				failure.RuleName = currentRule.Name()
-			}
-			if failure.Node != nil {
-				failure.Position = ToFailurePosition(failure.Node.Pos(), failure.Node.End(), f)
+		currentFailures := finalizeFailures(currentRule, currentRule.Apply(f, config), f)
+		for _, failure := range currentFailures {
+			if isSuppressed(failure, disabledIntervals) {
+				suppressed = append(suppressed, failure)
+			} else {
+				included = append(included, failure)
 			}
-			currentFailures[idx] = failure
 		}
+	}
+	return included, suppressed
+}
+
+func (f *File) lint(rules []Rule, rulesConfig RulesConfig, failures chan Failure) {
+	disabledIntervals := f.disabledIntervals(rules)
+	for _, currentRule := range rules {
+		config := rulesConfig[currentRule.Name()]
+		currentFailures := finalizeFailures(currentRule, currentRule.Apply(f, config), f)
 		currentFailures = f.filterFailures(currentFailures, disabledIntervals)
 		for _, failure := range currentFailures {
 			failures <- failure
@@ -105,11 +232,43 @@ func (f *File) lint(rules []Rule, rulesConfig RulesConfig, failures chan Failure
 	}
 }
 
+// finalizeFailures fills in each failure's RuleName and Position (from
+// currentRule and the node it matched), and, when currentRule implements
+// Fixable, strips Replacement from any failure CanFix reports as not
+// fixable - so ApplyFixes never sees an edit the rule itself disowns.
+func finalizeFailures(currentRule Rule, currentFailures []Failure, f *File) []Failure {
+	fixable, _ := currentRule.(Fixable)
+	for idx, failure := range currentFailures {
+		if failure.RuleName == "" {
+			failure.RuleName = currentRule.Name()
+		}
+		if failure.Node != nil {
+			failure.Position = ToFailurePosition(failure.Node.Pos(), failure.Node.End(), f)
+		}
+		if fixable != nil && len(failure.Replacement) > 0 && !fixable.CanFix(failure) {
+			failure.Replacement = nil
+		}
+		currentFailures[idx] = failure
+	}
+	return currentFailures
+}
+
 type enableDisableConfig struct {
 	enabled  bool
 	position int
 }
 
+// DisabledIntervals returns the revive:enable|disable intervals computed
+// for rules, flattened out of their per-rule map. Output formatters use it
+// to report which lines a failure was (or would have been) suppressed on.
+func (f *File) DisabledIntervals(rules []Rule) []DisabledInterval {
+	var out []DisabledInterval
+	for _, intervals := range f.disabledIntervals(rules) {
+		out = append(out, intervals...)
+	}
+	return out
+}
+
 func (f *File) disabledIntervals(rules []Rule) disabledIntervalsMap {
 	re := regexp.MustCompile(`^\s*revive:(enable|disable)(?:-(line|next-line))?(:|\s|$)`)
 
@@ -217,26 +376,29 @@ func (f *File) disabledIntervals(rules []Rule) disabledIntervalsMap {
 func (f *File) filterFailures(failures []Failure, disabledIntervals disabledIntervalsMap) []Failure {
 	result := []Failure{}
 	for _, failure := range failures {
-		fStart := failure.Position.Start.Line
-		fEnd := failure.Position.End.Line
-		intervals, ok := disabledIntervals[failure.RuleName]
-		if !ok {
+		if !isSuppressed(failure, disabledIntervals) {
 			result = append(result, failure)
-		} else {
-			include := true
-			for _, interval := range intervals {
-				intStart := interval.From.Line
-				intEnd := interval.To.Line
-				if (fStart >= intStart && fStart <= intEnd) ||
-					(fEnd >= intStart && fEnd <= intEnd) {
-					include = false
-					break
-				}
-			}
-			if include {
-				result = append(result, failure)
-			}
 		}
 	}
 	return result
+}
+
+// isSuppressed reports whether failure falls inside one of the
+// disabledIntervals recorded for its rule.
+func isSuppressed(failure Failure, disabledIntervals disabledIntervalsMap) bool {
+	fStart := failure.Position.Start.Line
+	fEnd := failure.Position.End.Line
+	intervals, ok := disabledIntervals[failure.RuleName]
+	if !ok {
+		return false
+	}
+	for _, interval := range intervals {
+		intStart := interval.From.Line
+		intEnd := interval.To.Line
+		if (fStart >= intStart && fStart <= intEnd) ||
+			(fEnd >= intStart && fEnd <= intEnd) {
+			return true
+		}
+	}
+	return false
 }
\ No newline at end of file
@@ -0,0 +1,106 @@
+package linter
+
+import (
+	"go/token"
+	"testing"
+)
+
+func newTestFile(t *testing.T, content string) *File {
+	t.Helper()
+	pkg := NewPackage(token.NewFileSet())
+	f, err := pkg.AddFile("test.go", []byte(content))
+	if err != nil {
+		t.Fatalf("AddFile: %v", err)
+	}
+	return f
+}
+
+const applyFixesSrc = `package p
+
+var x = 1
+`
+
+func TestApplyFixesRewritesContent(t *testing.T) {
+	f := newTestFile(t, applyFixesSrc)
+	pos := f.AST.Decls[0].Pos()
+
+	fixed, err := f.ApplyFixes([]Failure{{
+		Replacement: []Replacement{{Pos: pos, End: pos + token.Pos(len("var x = 1")), NewText: "var x = 2"}},
+	}})
+	if err != nil {
+		t.Fatalf("ApplyFixes: %v", err)
+	}
+	if got, want := string(fixed), "package p\n\nvar x = 2\n"; got != want {
+		t.Errorf("ApplyFixes content = %q, want %q", got, want)
+	}
+	if string(f.content) != applyFixesSrc {
+		t.Errorf("ApplyFixes mutated f.content; original should be left untouched")
+	}
+}
+
+func TestApplyFixesRejectsOverlaps(t *testing.T) {
+	f := newTestFile(t, applyFixesSrc)
+	pos := f.AST.Decls[0].Pos()
+
+	_, err := f.ApplyFixes([]Failure{{
+		Replacement: []Replacement{
+			{Pos: pos, End: pos + 5, NewText: "aaaaa"},
+			{Pos: pos + 2, End: pos + 7, NewText: "bbbbb"},
+		},
+	}})
+	if err == nil {
+		t.Fatal("ApplyFixes: expected an error for overlapping replacements, got nil")
+	}
+}
+
+func TestApplyFixesRejectsInvalidGo(t *testing.T) {
+	f := newTestFile(t, applyFixesSrc)
+	pos := f.AST.Decls[0].Pos()
+
+	_, err := f.ApplyFixes([]Failure{{
+		Replacement: []Replacement{{Pos: pos, End: pos + 3, NewText: "!!!"}},
+	}})
+	if err == nil {
+		t.Fatal("ApplyFixes: expected an error for a rewrite that isn't valid Go, got nil")
+	}
+}
+
+// alwaysFailRule reports one failure with a Replacement on every file.
+type alwaysFailRule struct{ canFix bool }
+
+func (alwaysFailRule) Name() string { return "always-fail" }
+
+func (r alwaysFailRule) Apply(f *File, _ RuleConfig) []Failure {
+	pos := f.AST.Pos()
+	return []Failure{{
+		Failure:     "always fails",
+		Node:        f.AST,
+		Replacement: []Replacement{{Pos: pos, End: pos, NewText: ""}},
+	}}
+}
+
+func (r alwaysFailRule) CanFix(Failure) bool { return r.canFix }
+
+func TestFinalizeFailuresStripsReplacementWhenCanFixRejects(t *testing.T) {
+	f := newTestFile(t, applyFixesSrc)
+
+	failures := f.Lint([]Rule{alwaysFailRule{canFix: false}}, RulesConfig{})
+	if len(failures) != 1 {
+		t.Fatalf("got %d failures, want 1", len(failures))
+	}
+	if failures[0].Replacement != nil {
+		t.Errorf("Replacement = %v, want nil since CanFix returned false", failures[0].Replacement)
+	}
+}
+
+func TestFinalizeFailuresKeepsReplacementWhenCanFixAccepts(t *testing.T) {
+	f := newTestFile(t, applyFixesSrc)
+
+	failures := f.Lint([]Rule{alwaysFailRule{canFix: true}}, RulesConfig{})
+	if len(failures) != 1 {
+		t.Fatalf("got %d failures, want 1", len(failures))
+	}
+	if failures[0].Replacement == nil {
+		t.Errorf("Replacement = nil, want it preserved since CanFix returned true")
+	}
+}
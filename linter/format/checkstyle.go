@@ -0,0 +1,68 @@
+package format
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/zm-stack/CCLint/linter"
+)
+
+// Checkstyle emits failures as a Checkstyle XML report, for CI dashboards
+// (e.g. Jenkins' Checkstyle plugin) that already know how to parse it.
+type Checkstyle struct{}
+
+// Name implements Formatter.
+func (Checkstyle) Name() string { return "checkstyle" }
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string           `xml:"name,attr"`
+	Errors []checkstyleItem `xml:"error"`
+}
+
+type checkstyleItem struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// Format implements Formatter.
+func (Checkstyle) Format(w io.Writer, failures []linter.Failure) error {
+	byFile := map[string]*checkstyleFile{}
+	var order []string
+	for _, failure := range failures {
+		name := failure.Position.Start.Filename
+		file, ok := byFile[name]
+		if !ok {
+			file = &checkstyleFile{Name: name}
+			byFile[name] = file
+			order = append(order, name)
+		}
+		file.Errors = append(file.Errors, checkstyleItem{
+			Line:     failure.Position.Start.Line,
+			Column:   failure.Position.Start.Column,
+			Severity: "warning",
+			Message:  failure.Failure,
+			Source:   failure.RuleName,
+		})
+	}
+
+	report := checkstyleReport{Version: "8.0"}
+	for _, name := range order {
+		report.Files = append(report.Files, *byFile[name])
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	return enc.Encode(report)
+}
@@ -0,0 +1,35 @@
+// Package format turns the stream of linter.Failure produced by a lint run
+// into the output a CLI or code-quality platform expects. Selecting one is
+// a single --format flag on the runner; see Formatters.
+package format
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/zm-stack/CCLint/linter"
+)
+
+// Formatter renders a batch of failures, grouped by file, to w.
+type Formatter interface {
+	// Name is the --format value that selects this Formatter.
+	Name() string
+	Format(w io.Writer, failures []linter.Failure) error
+}
+
+// Formatters lists every built-in Formatter, keyed by its --format name.
+var Formatters = map[string]Formatter{
+	"text":       Text{},
+	"json":       JSON{},
+	"sarif":      SARIF{},
+	"checkstyle": Checkstyle{},
+}
+
+// ByName looks up a built-in Formatter by its --format name.
+func ByName(name string) (Formatter, error) {
+	f, ok := Formatters[name]
+	if !ok {
+		return nil, fmt.Errorf("format: unknown formatter %q", name)
+	}
+	return f, nil
+}
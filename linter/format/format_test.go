@@ -0,0 +1,115 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"go/token"
+	"testing"
+
+	"github.com/zm-stack/CCLint/linter"
+)
+
+func sampleFailure() linter.Failure {
+	return linter.Failure{
+		Failure:  "something is wrong",
+		RuleName: "some-rule",
+		Position: linter.FailurePosition{
+			Start: token.Position{Filename: "f.go", Line: 3, Column: 2},
+			End:   token.Position{Filename: "f.go", Line: 3, Column: 10},
+		},
+	}
+}
+
+func TestSARIFFormatMarksSuppressedResults(t *testing.T) {
+	failure := sampleFailure()
+	sarif := SARIF{
+		Suppressed: []linter.DisabledInterval{{
+			RuleName: failure.RuleName,
+			From:     token.Position{Line: 1},
+			To:       token.Position{Line: 10},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := sarif.Format(&buf, []linter.Failure{failure}); err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("unmarshal SARIF output: %v", err)
+	}
+	results := log.Runs[0].Results
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	if len(results[0].Suppressions) != 1 || results[0].Suppressions[0].Kind != "inSource" {
+		t.Errorf("Suppressions = %#v, want one inSource suppression", results[0].Suppressions)
+	}
+}
+
+func TestSARIFFormatLeavesUnsuppressedResultsAlone(t *testing.T) {
+	failure := sampleFailure()
+	var buf bytes.Buffer
+	if err := (SARIF{}).Format(&buf, []linter.Failure{failure}); err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatalf("unmarshal SARIF output: %v", err)
+	}
+	results := log.Runs[0].Results
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	if results[0].Suppressions != nil {
+		t.Errorf("Suppressions = %#v, want nil with no DisabledIntervals", results[0].Suppressions)
+	}
+	if results[0].RuleID != failure.RuleName {
+		t.Errorf("RuleID = %q, want %q", results[0].RuleID, failure.RuleName)
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	failure := sampleFailure()
+	var buf bytes.Buffer
+	if err := (JSON{}).Format(&buf, []linter.Failure{failure}); err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+
+	var out []jsonFailure
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatalf("unmarshal JSON output: %v", err)
+	}
+	if len(out) != 1 {
+		t.Fatalf("got %d entries, want 1", len(out))
+	}
+	if out[0].Rule != failure.RuleName || out[0].File != "f.go" || out[0].Line != 3 || out[0].Column != 2 {
+		t.Errorf("got %#v, want a shape matching %#v", out[0], failure)
+	}
+}
+
+func TestCheckstyleFormat(t *testing.T) {
+	failure := sampleFailure()
+	var buf bytes.Buffer
+	if err := (Checkstyle{}).Format(&buf, []linter.Failure{failure}); err != nil {
+		t.Fatalf("Format: %v", err)
+	}
+
+	var report checkstyleReport
+	if err := xml.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("unmarshal Checkstyle output: %v\n%s", err, buf.String())
+	}
+	if len(report.Files) != 1 || report.Files[0].Name != "f.go" {
+		t.Fatalf("Files = %#v, want one file named f.go", report.Files)
+	}
+	if len(report.Files[0].Errors) != 1 {
+		t.Fatalf("Errors = %#v, want 1", report.Files[0].Errors)
+	}
+	item := report.Files[0].Errors[0]
+	if item.Line != 3 || item.Column != 2 || item.Source != failure.RuleName {
+		t.Errorf("got %#v, want a shape matching %#v", item, failure)
+	}
+}
@@ -0,0 +1,43 @@
+package format
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/zm-stack/CCLint/linter"
+)
+
+// JSON emits the failure stream as a single JSON array, one object per
+// failure, for tools that want to post-process CCLint's results.
+type JSON struct{}
+
+// Name implements Formatter.
+func (JSON) Name() string { return "json" }
+
+type jsonFailure struct {
+	Rule     string `json:"rule"`
+	Failure  string `json:"failure"`
+	Category string `json:"category,omitempty"`
+	Severity string `json:"severity,omitempty"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+}
+
+// Format implements Formatter.
+func (JSON) Format(w io.Writer, failures []linter.Failure) error {
+	out := make([]jsonFailure, 0, len(failures))
+	for _, failure := range failures {
+		out = append(out, jsonFailure{
+			Rule:     failure.RuleName,
+			Failure:  failure.Failure,
+			Category: failure.Category,
+			File:     failure.Position.Start.Filename,
+			Line:     failure.Position.Start.Line,
+			Column:   failure.Position.Start.Column,
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
@@ -0,0 +1,146 @@
+package format
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/zm-stack/CCLint/linter"
+)
+
+// SARIF emits failures as a SARIF 2.1.0 log, the format GitHub code
+// scanning and SonarQube (among others) ingest directly.
+type SARIF struct {
+	// Suppressed, if set, is consulted to mark a result as suppressed
+	// when it falls inside one of the intervals File.DisabledIntervals
+	// reported for its rule - mirroring the revive:enable|disable
+	// comments that already drive in-process filtering.
+	Suppressed []linter.DisabledInterval
+}
+
+// Name implements Formatter.
+func (SARIF) Name() string { return "sarif" }
+
+const sarifSchema = "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID       string             `json:"ruleId"`
+	Message      sarifMessage       `json:"message"`
+	Locations    []sarifLocation    `json:"locations"`
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+}
+
+type sarifSuppression struct {
+	Kind string `json:"kind"`
+}
+
+// Format implements Formatter.
+func (s SARIF) Format(w io.Writer, failures []linter.Failure) error {
+	ruleIDs := map[string]bool{}
+	var rules []sarifRule
+	results := make([]sarifResult, 0, len(failures))
+	for _, failure := range failures {
+		if !ruleIDs[failure.RuleName] {
+			ruleIDs[failure.RuleName] = true
+			rules = append(rules, sarifRule{ID: failure.RuleName})
+		}
+		result := sarifResult{
+			RuleID:  failure.RuleName,
+			Message: sarifMessage{Text: failure.Failure},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: failure.Position.Start.Filename},
+					Region: sarifRegion{
+						StartLine:   failure.Position.Start.Line,
+						StartColumn: failure.Position.Start.Column,
+						EndLine:     failure.Position.End.Line,
+						EndColumn:   failure.Position.End.Column,
+					},
+				},
+			}},
+		}
+		if s.suppressedBy(failure) {
+			result.Suppressions = []sarifSuppression{{Kind: "inSource"}}
+		}
+		results = append(results, result)
+	}
+
+	log := sarifLog{
+		Schema:  sarifSchema,
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: "CCLint", Rules: rules}},
+			Results: results,
+		}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
+
+// suppressedBy reports whether failure's start or end line falls inside
+// one of s.Suppressed's intervals for its rule, the same test File.lint
+// uses to drop a failure. File.lint drops suppressed failures before a
+// Formatter ever sees them; cli.Run adds them back for SARIF, from
+// File.LintWithSuppressed, so the report can show what was silenced.
+func (s SARIF) suppressedBy(failure linter.Failure) bool {
+	start, end := failure.Position.Start.Line, failure.Position.End.Line
+	for _, interval := range s.Suppressed {
+		if interval.RuleName != failure.RuleName {
+			continue
+		}
+		if (start >= interval.From.Line && start <= interval.To.Line) ||
+			(end >= interval.From.Line && end <= interval.To.Line) {
+			return true
+		}
+	}
+	return false
+}
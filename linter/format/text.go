@@ -0,0 +1,25 @@
+package format
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/zm-stack/CCLint/linter"
+)
+
+// Text is CCLint's original, human-readable output: one failure per line.
+type Text struct{}
+
+// Name implements Formatter.
+func (Text) Name() string { return "text" }
+
+// Format implements Formatter.
+func (Text) Format(w io.Writer, failures []linter.Failure) error {
+	for _, failure := range failures {
+		_, err := fmt.Fprintf(w, "%v: %s %s\n", failure.Position.Start, failure.Failure, failure.RuleName)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
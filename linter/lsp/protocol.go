@@ -0,0 +1,57 @@
+package lsp
+
+// The types below are the small slice of the LSP specification that the
+// server needs: positions, ranges, diagnostics and code actions. They are
+// hand-rolled rather than pulled from a dependency because the JSON shapes
+// are tiny and stable.
+
+// Position is a zero-based line/character offset, as required by LSP.
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// Range is a span between two Positions.
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// Severity mirrors the LSP DiagnosticSeverity enum.
+type Severity int
+
+// Diagnostic severities, as defined by the LSP spec.
+const (
+	SeverityError Severity = iota + 1
+	SeverityWarning
+	SeverityInformation
+	SeverityHint
+)
+
+// Diagnostic is an LSP textDocument/publishDiagnostics entry.
+type Diagnostic struct {
+	Range    Range    `json:"range"`
+	Severity Severity `json:"severity"`
+	Source   string   `json:"source,omitempty"`
+	Code     string   `json:"code,omitempty"`
+	Message  string   `json:"message"`
+}
+
+// CodeAction is an LSP textDocument/codeAction entry offering a fix.
+type CodeAction struct {
+	Title string        `json:"title"`
+	Kind  string        `json:"kind"`
+	Edit  WorkspaceEdit `json:"edit"`
+}
+
+// WorkspaceEdit is the minimal subset of LSP's WorkspaceEdit: a single
+// file's worth of text edits, keyed by document URI.
+type WorkspaceEdit struct {
+	Changes map[string][]TextEdit `json:"changes"`
+}
+
+// TextEdit replaces the text within Range with NewText.
+type TextEdit struct {
+	Range   Range  `json:"range"`
+	NewText string `json:"newText"`
+}
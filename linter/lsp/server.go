@@ -0,0 +1,152 @@
+// Package lsp exposes CCLint as a Language Server Protocol server, so
+// editors can surface rule failures live as users type.
+//
+// The server reuses linter.File, linter.Package and the Rule.Apply path
+// that the batch runner uses: on textDocument/didChange it only re-parses
+// the changed file and re-runs rules against it, keeping the rest of the
+// Package - and its TypesPkg/TypeInfo from the last Check - untouched, so
+// one keystroke costs one file's worth of parsing rather than a full
+// package type-check. The re-parsed File has no TypeInfo until the next
+// didOpen (or another event that calls Check): until then TypeOf and
+// ObjectOf return nil for it, and IsUntypedConst evaluates against the
+// TypesPkg from the last Check, which may be one or more edits stale;
+// rules that can't tolerate that should stick to the AST instead. Suppressions
+// via "revive:enable|disable" comments are honored identically to the
+// batch runner because they go through the same File.Lint path.
+package lsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/importer"
+	"go/token"
+
+	"github.com/zm-stack/CCLint/linter"
+)
+
+// Server holds the linting state for a single open workspace.
+type Server struct {
+	rules  []linter.Rule
+	config linter.RulesConfig
+
+	// pkgs maps a directory (one Go package) to its cached Package, so that
+	// didChange only has to touch the one file that actually changed.
+	pkgs map[string]*linter.Package
+
+	publish func(uri string, diagnostics []Diagnostic)
+}
+
+// NewServer creates an LSP server that lints with rules/config and reports
+// diagnostics through publish.
+func NewServer(rules []linter.Rule, config linter.RulesConfig, publish func(uri string, diagnostics []Diagnostic)) *Server {
+	return &Server{
+		rules:   rules,
+		config:  config,
+		pkgs:    map[string]*linter.Package{},
+		publish: publish,
+	}
+}
+
+// DidOpen handles textDocument/didOpen: it parses the file into its
+// package's Package (creating one if this is the first file seen for that
+// directory), type-checks the whole package so TypeOf/ObjectOf have
+// something to serve from the start, and publishes the initial
+// diagnostics.
+func (s *Server) DidOpen(uri, dir string, content []byte) error {
+	pkg, ok := s.pkgs[dir]
+	if !ok {
+		pkg = linter.NewPackage(token.NewFileSet())
+		s.pkgs[dir] = pkg
+	}
+	return s.relint(uri, dir, pkg, content, true)
+}
+
+// DidChange handles textDocument/didChange: it re-parses only the changed
+// file, keeping the rest of the package's Files and TypesPkg cached - no
+// type-check runs here, so one keystroke stays proportional to one file
+// regardless of package size - then re-runs rules against that file and
+// republishes its diagnostics.
+func (s *Server) DidChange(uri, dir string, content []byte) error {
+	pkg, ok := s.pkgs[dir]
+	if !ok {
+		return fmt.Errorf("lsp: didChange for %s before didOpen", uri)
+	}
+	return s.relint(uri, dir, pkg, content, false)
+}
+
+func (s *Server) relint(uri, dir string, pkg *linter.Package, content []byte, check bool) error {
+	f, err := pkg.AddFile(uri, content)
+	if err != nil {
+		// A parse error still needs to reach the editor; report it as a
+		// single diagnostic rather than dropping the publish entirely.
+		s.publish(uri, []Diagnostic{{
+			Range:    Range{},
+			Severity: SeverityError,
+			Message:  err.Error(),
+		}})
+		return nil
+	}
+
+	if check {
+		pkg.Check(dir, importer.Default())
+	}
+
+	failures := f.Lint(s.rules, s.config)
+	diagnostics := make([]Diagnostic, 0, len(failures))
+	for _, failure := range failures {
+		diagnostics = append(diagnostics, diagnosticFromFailure(failure))
+	}
+	s.publish(uri, diagnostics)
+	return nil
+}
+
+// CodeActions returns the quick fixes available for failures that carry a
+// Replacement, translated into LSP text edits against f.
+func (s *Server) CodeActions(uri string, f *linter.File, failures []linter.Failure) []CodeAction {
+	var actions []CodeAction
+	for _, failure := range failures {
+		if len(failure.Replacement) == 0 {
+			continue
+		}
+		edits := make([]TextEdit, 0, len(failure.Replacement))
+		for _, r := range failure.Replacement {
+			start := f.ToPosition(r.Pos)
+			end := f.ToPosition(r.End)
+			edits = append(edits, TextEdit{
+				Range: Range{
+					Start: Position{Line: start.Line - 1, Character: start.Column - 1},
+					End:   Position{Line: end.Line - 1, Character: end.Column - 1},
+				},
+				NewText: r.NewText,
+			})
+		}
+		actions = append(actions, CodeAction{
+			Title: fmt.Sprintf("Fix: %s (%s)", failure.Failure, failure.RuleName),
+			Kind:  "quickfix",
+			Edit:  WorkspaceEdit{Changes: map[string][]TextEdit{uri: edits}},
+		})
+	}
+	return actions
+}
+
+func diagnosticFromFailure(f linter.Failure) Diagnostic {
+	return Diagnostic{
+		Range: Range{
+			Start: Position{Line: f.Position.Start.Line - 1, Character: f.Position.Start.Column - 1},
+			End:   Position{Line: f.Position.End.Line - 1, Character: f.Position.End.Column - 1},
+		},
+		Severity: SeverityWarning,
+		Source:   "cclint",
+		Code:     f.RuleName,
+		Message:  f.Failure,
+	}
+}
+
+// MarshalDiagnostics is a convenience helper for transports that need the
+// raw JSON-RPC payload for a publishDiagnostics notification.
+func MarshalDiagnostics(uri string, diagnostics []Diagnostic) ([]byte, error) {
+	return json.Marshal(struct {
+		URI         string       `json:"uri"`
+		Diagnostics []Diagnostic `json:"diagnostics"`
+	}{URI: uri, Diagnostics: diagnostics})
+}
@@ -0,0 +1,56 @@
+package lsp
+
+import (
+	"go/ast"
+	"testing"
+)
+
+func TestDidOpenTypeChecksThePackage(t *testing.T) {
+	var published []Diagnostic
+	s := NewServer(nil, nil, func(_ string, diagnostics []Diagnostic) {
+		published = diagnostics
+	})
+
+	const src = "package p\n\nvar x = 1\n"
+	if err := s.DidOpen("file:///p/f.go", "/p", []byte(src)); err != nil {
+		t.Fatalf("DidOpen: %v", err)
+	}
+	if published == nil {
+		t.Fatal("DidOpen never published diagnostics")
+	}
+
+	f, ok := s.pkgs["/p"].File("file:///p/f.go")
+	if !ok {
+		t.Fatal("file not found in package after DidOpen")
+	}
+	value := f.AST.Decls[0].(*ast.GenDecl).Specs[0].(*ast.ValueSpec).Values[0]
+	if f.TypeOf(value) == nil {
+		t.Error("TypeOf(x's value) = nil, want DidOpen to have called Package.Check")
+	}
+}
+
+func TestDidChangeDoesNotReTypeCheck(t *testing.T) {
+	s := NewServer(nil, nil, func(_ string, _ []Diagnostic) {})
+
+	const src = "package p\n\nvar x = 1\n"
+	if err := s.DidOpen("file:///p/f.go", "/p", []byte(src)); err != nil {
+		t.Fatalf("DidOpen: %v", err)
+	}
+	pkg := s.pkgs["/p"]
+	typesPkgAfterOpen := pkg.TypesPkg
+
+	const changed = "package p\n\nvar x = 2\n"
+	if err := s.DidChange("file:///p/f.go", "/p", []byte(changed)); err != nil {
+		t.Fatalf("DidChange: %v", err)
+	}
+	if pkg.TypesPkg != typesPkgAfterOpen {
+		t.Error("DidChange replaced TypesPkg; it should leave the package's last Check result untouched")
+	}
+}
+
+func TestDidChangeBeforeDidOpenErrors(t *testing.T) {
+	s := NewServer(nil, nil, func(_ string, _ []Diagnostic) {})
+	if err := s.DidChange("file:///p/f.go", "/p", []byte("package p\n")); err == nil {
+		t.Error("DidChange before DidOpen: got nil error, want one")
+	}
+}
@@ -0,0 +1,197 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/zm-stack/CCLint/linter"
+)
+
+// Serve runs a Server as a Language Server Protocol server speaking the
+// standard Content-Length-framed JSON-RPC 2.0 transport over r/w - stdin
+// and stdout, when called from a CLI. It handles initialize,
+// textDocument/didOpen, textDocument/didChange and shutdown/exit; any
+// other request or notification is accepted and ignored, per the LSP
+// spec's guidance that unrecognized messages should be dropped rather
+// than treated as errors. Serve blocks until the client sends exit or r
+// reaches EOF.
+func Serve(r io.Reader, w io.Writer, rules []linter.Rule, config linter.RulesConfig) error {
+	var mu sync.Mutex
+	send := func(msg jsonrpcMessage) error {
+		msg.JSONRPC = "2.0"
+		body, err := json.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if _, err := fmt.Fprintf(w, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+			return err
+		}
+		_, err = w.Write(body)
+		return err
+	}
+
+	s := NewServer(rules, config, func(uri string, diagnostics []Diagnostic) {
+		params, err := json.Marshal(publishDiagnosticsParams{URI: uri, Diagnostics: diagnostics})
+		if err != nil {
+			return
+		}
+		send(jsonrpcMessage{Method: "textDocument/publishDiagnostics", Params: params})
+	})
+
+	br := bufio.NewReader(r)
+	for {
+		msg, err := readMessage(br)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch msg.Method {
+		case "initialize":
+			result, err := json.Marshal(initializeResult)
+			if err != nil {
+				return err
+			}
+			if err := send(jsonrpcMessage{ID: msg.ID, Result: result}); err != nil {
+				return err
+			}
+		case "textDocument/didOpen":
+			handleDidOpen(s, msg.Params)
+		case "textDocument/didChange":
+			handleDidChange(s, msg.Params)
+		case "shutdown":
+			if err := send(jsonrpcMessage{ID: msg.ID, Result: json.RawMessage("null")}); err != nil {
+				return err
+			}
+		case "exit":
+			return nil
+		}
+	}
+}
+
+// jsonrpcMessage is the subset of the JSON-RPC 2.0 envelope Serve needs:
+// requests and notifications coming in (Method/Params, ID if a request
+// expects a reply) and responses/notifications going out (ID/Result, or
+// Method/Params for a server-initiated notification).
+type jsonrpcMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+}
+
+// readMessage reads one Content-Length-framed JSON-RPC message from br.
+func readMessage(br *bufio.Reader) (jsonrpcMessage, error) {
+	contentLength := -1
+	for {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return jsonrpcMessage{}, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		if strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return jsonrpcMessage{}, fmt.Errorf("lsp: invalid Content-Length %q: %w", value, err)
+			}
+			contentLength = n
+		}
+	}
+	if contentLength < 0 {
+		return jsonrpcMessage{}, fmt.Errorf("lsp: message header missing Content-Length")
+	}
+
+	body := make([]byte, contentLength)
+	if _, err := io.ReadFull(br, body); err != nil {
+		return jsonrpcMessage{}, err
+	}
+	var msg jsonrpcMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		return jsonrpcMessage{}, fmt.Errorf("lsp: invalid JSON-RPC message: %w", err)
+	}
+	return msg, nil
+}
+
+// initializeResult is the InitializeResult Serve replies with: full-text
+// sync (Server re-lints from the whole document on every didOpen/didChange
+// rather than incremental edits) plus the codeAction capability CodeActions
+// backs.
+var initializeResult = struct {
+	Capabilities struct {
+		TextDocumentSync   int  `json:"textDocumentSync"`
+		CodeActionProvider bool `json:"codeActionProvider"`
+	} `json:"capabilities"`
+}{}
+
+func init() {
+	initializeResult.Capabilities.TextDocumentSync = 1 // Full
+	initializeResult.Capabilities.CodeActionProvider = true
+}
+
+type publishDiagnosticsParams struct {
+	URI         string       `json:"uri"`
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+func handleDidOpen(s *Server, params json.RawMessage) {
+	var p struct {
+		TextDocument struct {
+			URI  string `json:"uri"`
+			Text string `json:"text"`
+		} `json:"textDocument"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil {
+		return
+	}
+	path := uriToPath(p.TextDocument.URI)
+	s.DidOpen(p.TextDocument.URI, filepath.Dir(path), []byte(p.TextDocument.Text))
+}
+
+func handleDidChange(s *Server, params json.RawMessage) {
+	var p struct {
+		TextDocument struct {
+			URI string `json:"uri"`
+		} `json:"textDocument"`
+		ContentChanges []struct {
+			Text string `json:"text"`
+		} `json:"contentChanges"`
+	}
+	if err := json.Unmarshal(params, &p); err != nil || len(p.ContentChanges) == 0 {
+		return
+	}
+	// Full-text sync only (see initializeResult): the last entry in
+	// ContentChanges always carries the whole new document.
+	text := p.ContentChanges[len(p.ContentChanges)-1].Text
+	path := uriToPath(p.TextDocument.URI)
+	s.DidChange(p.TextDocument.URI, filepath.Dir(path), []byte(text))
+}
+
+// uriToPath converts a textDocument "file://" URI into a filesystem path.
+// URIs that don't parse as a file URI are returned unchanged, so a caller
+// that passed a bare path (e.g. a test) still works.
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return uri
+	}
+	return filepath.FromSlash(u.Path)
+}
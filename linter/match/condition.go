@@ -0,0 +1,116 @@
+package match
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+)
+
+// evalCondition evaluates a small subset of Go boolean expressions against
+// the bindings produced by a successful pattern match: equality/inequality
+// between a bound variable's rendered source (or, through the .Value and
+// .Name accessors, a BasicLit's value or an Ident's name) and a string or
+// another bound variable, combined with
+// && and ||. It is intentionally far short of a full Go evaluator - rules
+// that need more should be written directly against the Rule interface.
+func evalCondition(src string, bindings map[string]interface{}, render func(ast.Node) string) (bool, error) {
+	if src == "" {
+		return true, nil
+	}
+	expr, err := parser.ParseExpr(src)
+	if err != nil {
+		return false, fmt.Errorf("match: invalid condition %q: %w", src, err)
+	}
+	return evalExpr(expr, bindings, render)
+}
+
+func evalExpr(expr ast.Expr, bindings map[string]interface{}, render func(ast.Node) string) (bool, error) {
+	switch e := expr.(type) {
+	case *ast.ParenExpr:
+		return evalExpr(e.X, bindings, render)
+	case *ast.BinaryExpr:
+		switch e.Op {
+		case token.LAND:
+			l, err := evalExpr(e.X, bindings, render)
+			if err != nil || !l {
+				return false, err
+			}
+			return evalExpr(e.Y, bindings, render)
+		case token.LOR:
+			l, err := evalExpr(e.X, bindings, render)
+			if err != nil {
+				return false, err
+			}
+			if l {
+				return true, nil
+			}
+			return evalExpr(e.Y, bindings, render)
+		case token.EQL, token.NEQ:
+			lv, err := evalValue(e.X, bindings, render)
+			if err != nil {
+				return false, err
+			}
+			rv, err := evalValue(e.Y, bindings, render)
+			if err != nil {
+				return false, err
+			}
+			eq := lv == rv
+			if e.Op == token.NEQ {
+				eq = !eq
+			}
+			return eq, nil
+		}
+	}
+	return false, fmt.Errorf("match: unsupported condition expression %q", render(expr))
+}
+
+// evalValue resolves a leaf of a condition expression to the string it
+// should be compared against.
+func evalValue(expr ast.Expr, bindings map[string]interface{}, render func(ast.Node) string) (string, error) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind == token.STRING {
+			return strconv.Unquote(e.Value)
+		}
+		return e.Value, nil
+	case *ast.SelectorExpr:
+		base, ok := e.X.(*ast.Ident)
+		if !ok {
+			break
+		}
+		node, ok := bindings[base.Name].(ast.Node)
+		if !ok {
+			return "", fmt.Errorf("match: condition refers to unbound variable %q", base.Name)
+		}
+		return fieldText(node, e.Sel.Name, render)
+	case *ast.Ident:
+		node, ok := bindings[e.Name].(ast.Node)
+		if !ok {
+			return "", fmt.Errorf("match: condition refers to unbound variable %q", e.Name)
+		}
+		return render(node), nil
+	}
+	return "", fmt.Errorf("match: unsupported condition operand %q", render(expr))
+}
+
+// fieldText supports the common `v.Value`/`v.Name` accessors condition
+// snippets use to read a bound BasicLit's value or Ident's name without
+// needing the full rendered source of the node.
+func fieldText(node ast.Node, field string, render func(ast.Node) string) (string, error) {
+	switch n := node.(type) {
+	case *ast.BasicLit:
+		if field == "Value" {
+			if n.Kind == token.STRING {
+				return strconv.Unquote(n.Value)
+			}
+			return n.Value, nil
+		}
+	case *ast.Ident:
+		if field == "Name" {
+			return n.Name, nil
+		}
+	}
+	return "", fmt.Errorf("match: unknown field %q on %T", field, node)
+}
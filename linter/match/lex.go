@@ -0,0 +1,108 @@
+package match
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokLParen tokenKind = iota
+	tokRParen
+	tokColon
+	tokLess
+	tokGreater
+	tokEllipsis
+	tokString
+	tokIdent
+	tokEOF
+)
+
+type lexToken struct {
+	kind tokenKind
+	text string
+}
+
+// lexer splits a pattern string into the tokens consumed by parsePattern.
+type lexer struct {
+	src []rune
+	pos int
+}
+
+func newLexer(src string) *lexer { return &lexer{src: []rune(src)} }
+
+func (l *lexer) peekRune() rune {
+	if l.pos >= len(l.src) {
+		return 0
+	}
+	return l.src[l.pos]
+}
+
+func (l *lexer) skipSpace() {
+	for l.pos < len(l.src) && unicode.IsSpace(l.src[l.pos]) {
+		l.pos++
+	}
+}
+
+func (l *lexer) next() (lexToken, error) {
+	l.skipSpace()
+	if l.pos >= len(l.src) {
+		return lexToken{kind: tokEOF}, nil
+	}
+
+	c := l.src[l.pos]
+	switch c {
+	case '(':
+		l.pos++
+		return lexToken{kind: tokLParen, text: "("}, nil
+	case ')':
+		l.pos++
+		return lexToken{kind: tokRParen, text: ")"}, nil
+	case ':':
+		l.pos++
+		return lexToken{kind: tokColon, text: ":"}, nil
+	case '<':
+		l.pos++
+		return lexToken{kind: tokLess, text: "<"}, nil
+	case '>':
+		l.pos++
+		return lexToken{kind: tokGreater, text: ">"}, nil
+	case '"':
+		return l.lexString()
+	}
+
+	if c == '.' && strings.HasPrefix(string(l.src[l.pos:]), "...") {
+		l.pos += 3
+		return lexToken{kind: tokEllipsis, text: "..."}, nil
+	}
+
+	if isIdentRune(c) {
+		start := l.pos
+		for l.pos < len(l.src) && isIdentRune(l.src[l.pos]) {
+			l.pos++
+		}
+		return lexToken{kind: tokIdent, text: string(l.src[start:l.pos])}, nil
+	}
+
+	return lexToken{}, fmt.Errorf("match: unexpected character %q", c)
+}
+
+func (l *lexer) lexString() (lexToken, error) {
+	l.pos++ // opening quote
+	start := l.pos
+	for l.pos < len(l.src) && l.src[l.pos] != '"' {
+		l.pos++
+	}
+	if l.pos >= len(l.src) {
+		return lexToken{}, fmt.Errorf("match: unterminated string literal")
+	}
+	text := string(l.src[start:l.pos])
+	l.pos++ // closing quote
+	return lexToken{kind: tokString, text: text}, nil
+}
+
+func isIdentRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '!' || r == '=' || r == '%'
+}
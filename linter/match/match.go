@@ -0,0 +1,253 @@
+package match
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/zm-stack/CCLint/linter"
+)
+
+// LoadRules compiles every "pattern [&& condition] => message" line in the
+// file at path into a linter.Rule. The returned rules can be appended to
+// the set passed to a Runner or lsp.Server exactly like built-in rules -
+// match does not require the caller to write any Go.
+func LoadRules(path string) ([]linter.Rule, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("match: %w", err)
+	}
+	defer f.Close()
+
+	var rules []linter.Rule
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		pat, cond, message, err := parseRuleLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("match: %s:%d: %w", path, lineNo, err)
+		}
+		rules = append(rules, &Rule{
+			name:      path + ":" + strconv.Itoa(lineNo),
+			pattern:   pat,
+			condition: cond,
+			message:   message,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("match: %w", err)
+	}
+	return rules, nil
+}
+
+// Name implements linter.Rule.
+func (r *Rule) Name() string { return r.name }
+
+// Apply implements linter.Rule: it walks f's AST, attempts to unify every
+// node against r.pattern, and emits a Failure for each match whose
+// condition (if any) also holds.
+func (r *Rule) Apply(f *linter.File, _ linter.RuleConfig) []linter.Failure {
+	var failures []linter.Failure
+	ast.Inspect(f.AST, func(n ast.Node) bool {
+		if n == nil {
+			return false
+		}
+		render := func(node ast.Node) string { return f.Render(node) }
+		bindings := map[string]interface{}{}
+		if !unify(r.pattern, n, bindings, render) {
+			return true
+		}
+		ok, err := evalCondition(r.condition, bindings, render)
+		if err != nil || !ok {
+			return true
+		}
+		failures = append(failures, linter.Failure{
+			Failure:  r.message,
+			RuleName: r.name,
+			Node:     n,
+		})
+		return true
+	})
+	return failures
+}
+
+// unify attempts to match pattern p against AST node n, recording any
+// variable bindings it makes into bindings. It returns false, leaving
+// bindings partially populated, as soon as a constraint fails; callers
+// should start from a fresh bindings map per top-level attempt. render is
+// threaded down to sameNode so that repeated-variable bindings are compared
+// by their rendered source rather than by struct layout.
+func unify(p pattern, n ast.Node, bindings map[string]interface{}, render func(ast.Node) string) bool {
+	switch pt := p.(type) {
+	case wildcard:
+		return n != nil
+
+	case typedHole:
+		return n != nil && kindMatches(n, pt.Kind)
+
+	case variable:
+		if n == nil {
+			return false
+		}
+		if pt.Kind != "" && !kindMatches(n, pt.Kind) {
+			return false
+		}
+		if existing, ok := bindings[pt.Name]; ok {
+			existingNode, _ := existing.(ast.Node)
+			return sameNode(existingNode, n, render)
+		}
+		bindings[pt.Name] = n
+		return true
+
+	case literal:
+		return n != nil && literalMatches(pt.Text, n)
+
+	case *nodePattern:
+		if n == nil || !kindMatches(n, pt.Kind) {
+			return false
+		}
+		v := reflect.ValueOf(n)
+		if v.Kind() == reflect.Ptr {
+			v = v.Elem()
+		}
+		for _, fp := range pt.Fields {
+			fv := v.FieldByName(fp.Name)
+			if !fv.IsValid() {
+				return false
+			}
+			if !unifyField(fp.Pat, fv, bindings, render) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// unifyField matches a sub-pattern against a single struct field of an
+// ast.Node, dispatching on the field's reflected kind: a nested node, a
+// token.Token/string leaf (compared as text against a literal, or bound
+// to a variable), or a
+// slice of nodes (matched via listPattern).
+func unifyField(p pattern, fv reflect.Value, bindings map[string]interface{}, render func(ast.Node) string) bool {
+	if lp, ok := p.(listPattern); ok {
+		return unifyList(lp, fv, bindings, render)
+	}
+
+	switch fv.Kind() {
+	case reflect.Interface, reflect.Ptr:
+		if fv.IsNil() {
+			return unify(p, nil, bindings, render)
+		}
+		node, ok := fv.Interface().(ast.Node)
+		if !ok {
+			return false
+		}
+		return unify(p, node, bindings, render)
+	default:
+		// Leaves such as token.Token, string, bool: compare their default
+		// formatting against a literal or bind them to a variable.
+		text := fmt.Sprintf("%v", fv.Interface())
+		switch pt := p.(type) {
+		case literal:
+			return pt.Text == text
+		case wildcard:
+			return true
+		case variable:
+			// Leaves aren't ast.Node, so they can't be bound as one; treat
+			// the variable as an opaque textual constraint instead.
+			if existing, ok := bindings["$"+pt.Name]; ok {
+				return existing.(leafBinding).text == text
+			}
+			bindings["$"+pt.Name] = leafBinding{text: text}
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+// leafBinding records a bound non-node (token.Token/string/bool) field so
+// repeated uses of the same variable name can be checked for equality.
+type leafBinding struct{ text string }
+
+func unifyList(lp listPattern, fv reflect.Value, bindings map[string]interface{}, render func(ast.Node) string) bool {
+	if fv.Kind() != reflect.Slice {
+		return false
+	}
+	if fv.Len() < len(lp.Elems) {
+		return false
+	}
+	if !lp.Open && fv.Len() != len(lp.Elems) {
+		return false
+	}
+	for i, elemPat := range lp.Elems {
+		item := fv.Index(i)
+		node, _ := item.Interface().(ast.Node)
+		if !unify(elemPat, node, bindings, render) {
+			return false
+		}
+	}
+	return true
+}
+
+// kindMatches reports whether n satisfies the kind name from a pattern:
+// either its exact go/ast type name (e.g. "BinaryExpr", "Ident"), or one
+// of the broad interface kinds "Expr", "Stmt", "Decl", "Node".
+func kindMatches(n ast.Node, kind string) bool {
+	switch kind {
+	case "Node":
+		return true
+	case "Expr":
+		_, ok := n.(ast.Expr)
+		return ok
+	case "Stmt":
+		_, ok := n.(ast.Stmt)
+		return ok
+	case "Decl":
+		_, ok := n.(ast.Decl)
+		return ok
+	}
+	t := reflect.TypeOf(n)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name() == kind
+}
+
+// sameNode reports whether two already-bound nodes are the same repeated
+// occurrence of a pattern variable: the same pointer, or (since distinct
+// nodes at different source offsets are never ==) identical rendered
+// source text. Comparing via render rather than fmt.Sprintf matters
+// because %v on a node dumps its struct fields - including Pos/ValuePos -
+// so two textually-identical literals at different offsets would
+// otherwise never compare equal.
+func sameNode(a, b ast.Node, render func(ast.Node) string) bool {
+	return a == b || render(a) == render(b)
+}
+
+// literalMatches compares a pattern's quoted text against the node it
+// matched: BasicLit compares its unquoted value, Ident its name, and
+// everything else falls back to its default formatting.
+func literalMatches(text string, n ast.Node) bool {
+	switch v := n.(type) {
+	case *ast.BasicLit:
+		if s, err := strconv.Unquote(v.Value); err == nil {
+			return s == text
+		}
+		return v.Value == text
+	case *ast.Ident:
+		return v.Name == text
+	default:
+		return fmt.Sprintf("%v", n) == text
+	}
+}
@@ -0,0 +1,126 @@
+package match
+
+import (
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zm-stack/CCLint/linter"
+)
+
+func TestParseRuleLineListPattern(t *testing.T) {
+	pat, cond, message, err := parseRuleLine(
+		`(CallExpr :Args (list a:Ident ...)) => pointless call`)
+	if err != nil {
+		t.Fatalf("parseRuleLine: %v", err)
+	}
+	if cond != "" {
+		t.Errorf("cond = %q, want empty", cond)
+	}
+	if message != "pointless call" {
+		t.Errorf("message = %q, want %q", message, "pointless call")
+	}
+
+	n, ok := pat.(*nodePattern)
+	if !ok || n.Kind != "CallExpr" {
+		t.Fatalf("pattern = %#v, want a CallExpr nodePattern", pat)
+	}
+	if len(n.Fields) != 1 || n.Fields[0].Name != "Args" {
+		t.Fatalf("fields = %#v, want a single Args field", n.Fields)
+	}
+
+	lp, ok := n.Fields[0].Pat.(listPattern)
+	if !ok {
+		t.Fatalf("Args pattern = %#v, want a listPattern", n.Fields[0].Pat)
+	}
+	if !lp.Open {
+		t.Error("listPattern.Open = false, want true for a trailing ...")
+	}
+	if len(lp.Elems) != 1 {
+		t.Fatalf("listPattern.Elems = %#v, want exactly one element", lp.Elems)
+	}
+	if v, ok := lp.Elems[0].(variable); !ok || v.Name != "a" || v.Kind != "Ident" {
+		t.Errorf("listPattern.Elems[0] = %#v, want variable{Name: \"a\", Kind: \"Ident\"}", lp.Elems[0])
+	}
+}
+
+func TestParseRuleLineClosedList(t *testing.T) {
+	pat, _, _, err := parseRuleLine(`(CallExpr :Args (list a:Ident)) => msg`)
+	if err != nil {
+		t.Fatalf("parseRuleLine: %v", err)
+	}
+	lp := pat.(*nodePattern).Fields[0].Pat.(listPattern)
+	if lp.Open {
+		t.Error("listPattern.Open = true, want false without a trailing ...")
+	}
+}
+
+func TestRepeatedVariableBindingComparesRenderedText(t *testing.T) {
+	dir := t.TempDir()
+	rulesPath := filepath.Join(dir, "rules.txt")
+	rule := `(BinaryExpr :Op "==" :X x:BasicLit :Y x:BasicLit) => suspicious self-comparison
+`
+	if err := os.WriteFile(rulesPath, []byte(rule), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	rules, err := LoadRules(rulesPath)
+	if err != nil {
+		t.Fatalf("LoadRules: %v", err)
+	}
+
+	src := `package p
+
+func f() (bool, bool) {
+	return 1 == 1, 1 == 2
+}
+`
+	pkg := linter.NewPackage(token.NewFileSet())
+	f, err := pkg.AddFile("f.go", []byte(src))
+	if err != nil {
+		t.Fatalf("AddFile: %v", err)
+	}
+
+	failures := rules[0].Apply(f, linter.RuleConfig{})
+	if len(failures) != 1 {
+		t.Fatalf("got %d failures, want 1 (only `1 == 1` repeats the same rendered literal)", len(failures))
+	}
+}
+
+func TestLoadRulesAndApply(t *testing.T) {
+	dir := t.TempDir()
+	rulesPath := filepath.Join(dir, "rules.txt")
+	rule := `(BinaryExpr :Op "==" :X (CallExpr :Fun sel:Ident :Args (list arg:Ident)) :Y lit:BasicLit) && sel.Name == "len" && lit.Value == "0" => avoid comparing len() to 0
+`
+	if err := os.WriteFile(rulesPath, []byte(rule), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	rules, err := LoadRules(rulesPath)
+	if err != nil {
+		t.Fatalf("LoadRules: %v", err)
+	}
+	if len(rules) != 1 {
+		t.Fatalf("got %d rules, want 1", len(rules))
+	}
+
+	src := `package p
+
+func f(s []int) bool {
+	return len(s) == 0
+}
+`
+	pkg := linter.NewPackage(token.NewFileSet())
+	f, err := pkg.AddFile("f.go", []byte(src))
+	if err != nil {
+		t.Fatalf("AddFile: %v", err)
+	}
+
+	failures := rules[0].Apply(f, linter.RuleConfig{})
+	if len(failures) != 1 {
+		t.Fatalf("got %d failures, want 1", len(failures))
+	}
+	if failures[0].Failure != "avoid comparing len() to 0" {
+		t.Errorf("Failure = %q, want %q", failures[0].Failure, "avoid comparing len() to 0")
+	}
+}
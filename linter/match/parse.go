@@ -0,0 +1,239 @@
+package match
+
+import (
+	"fmt"
+	"strings"
+)
+
+// patternParser turns a token stream from a lexer into a pattern tree.
+type patternParser struct {
+	lex  *lexer
+	cur  lexToken
+	init bool
+}
+
+func newParser(src string) (*patternParser, error) {
+	p := &patternParser{lex: newLexer(src)}
+	return p, p.advance()
+}
+
+func (p *patternParser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.cur = tok
+	p.init = true
+	return nil
+}
+
+func (p *patternParser) parsePattern() (pattern, error) {
+	switch p.cur.kind {
+	case tokLParen:
+		return p.parseNode()
+	case tokLess:
+		return p.parseTypedHole()
+	case tokString:
+		lit := literal{Text: p.cur.Text()}
+		return lit, p.advance()
+	case tokIdent:
+		return p.parseIdentPattern()
+	default:
+		return nil, fmt.Errorf("match: unexpected token %q in pattern", p.cur.text)
+	}
+}
+
+func (lt lexToken) Text() string { return lt.text }
+
+// parseIdentPattern handles `_`, `name`, and `name:Kind`.
+func (p *patternParser) parseIdentPattern() (pattern, error) {
+	name := p.cur.text
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if name == "_" {
+		return wildcard{}, nil
+	}
+	if p.cur.kind == tokColon {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.cur.kind != tokIdent {
+			return nil, fmt.Errorf("match: expected kind after %q:", name)
+		}
+		kind := p.cur.text
+		return variable{Name: name, Kind: kind}, p.advance()
+	}
+	return variable{Name: name}, nil
+}
+
+// parseTypedHole handles `<Kind>`.
+func (p *patternParser) parseTypedHole() (pattern, error) {
+	if err := p.advance(); err != nil { // consume '<'
+		return nil, err
+	}
+	if p.cur.kind != tokIdent {
+		return nil, fmt.Errorf("match: expected kind name inside <...>")
+	}
+	kind := p.cur.text
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.cur.kind != tokGreater {
+		return nil, fmt.Errorf("match: expected > to close <%s", kind)
+	}
+	return typedHole{Kind: kind}, p.advance()
+}
+
+// parseNode handles `(Kind :field pattern ...)`.
+func (p *patternParser) parseNode() (pattern, error) {
+	if err := p.advance(); err != nil { // consume '('
+		return nil, err
+	}
+	if p.cur.kind != tokIdent {
+		return nil, fmt.Errorf("match: expected node kind after (")
+	}
+	n := &nodePattern{Kind: p.cur.text}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	for p.cur.kind == tokColon {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		if p.cur.kind != tokIdent {
+			return nil, fmt.Errorf("match: expected field name after :")
+		}
+		field := p.cur.text
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		fp, err := p.parseFieldValue()
+		if err != nil {
+			return nil, err
+		}
+		n.Fields = append(n.Fields, fieldPattern{Name: field, Pat: fp})
+	}
+
+	if p.cur.kind != tokRParen {
+		return nil, fmt.Errorf("match: expected ) to close (%s ...", n.Kind)
+	}
+	return n, p.advance()
+}
+
+// parseFieldValue parses a field's value: a `(list p1 p2 ...)` form when
+// the '(' the parser is on opens one (see isListStart), otherwise a
+// single pattern.
+func (p *patternParser) parseFieldValue() (pattern, error) {
+	if p.cur.kind == tokLParen && p.isListStart() {
+		return p.parseList()
+	}
+	return p.parsePattern()
+}
+
+// isListStart reports whether the '(' the parser is currently positioned
+// on opens a `(list p1 p2 ...)` form rather than an ordinary nested node
+// pattern such as `(SelectorExpr ...)`. It peeks one token ahead - past
+// the '(' - and rewinds the lexer/parser state before returning, so
+// callers can fall through to parsePattern unaffected when it's false.
+func (p *patternParser) isListStart() bool {
+	savedPos := p.lex.pos
+	savedCur := p.cur
+	defer func() {
+		p.lex.pos = savedPos
+		p.cur = savedCur
+	}()
+
+	if err := p.advance(); err != nil { // consume '('
+		return false
+	}
+	return p.cur.kind == tokIdent && p.cur.text == "list"
+}
+
+func (p *patternParser) parseList() (pattern, error) {
+	if err := p.advance(); err != nil { // consume '('
+		return nil, err
+	}
+	if p.cur.kind != tokIdent || p.cur.text != "list" {
+		return nil, fmt.Errorf("match: expected 'list' keyword")
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	lp := listPattern{}
+	for p.cur.kind != tokRParen {
+		if p.cur.kind == tokEllipsis {
+			lp.Open = true
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		elem, err := p.parsePattern()
+		if err != nil {
+			return nil, err
+		}
+		lp.Elems = append(lp.Elems, elem)
+	}
+	return lp, p.advance()
+}
+
+// parseRuleLine parses one "pattern [&& condition] => message" line.
+func parseRuleLine(line string) (pattern, string, string, error) {
+	arrow := strings.LastIndex(line, "=>")
+	if arrow == -1 {
+		return nil, "", "", fmt.Errorf("match: missing => in rule line %q", line)
+	}
+	head := strings.TrimSpace(line[:arrow])
+	message := strings.TrimSpace(line[arrow+2:])
+	if message == "" {
+		return nil, "", "", fmt.Errorf("match: empty message in rule line %q", line)
+	}
+
+	patSrc, cond := head, ""
+	if idx := topLevelAnd(head); idx != -1 {
+		patSrc = strings.TrimSpace(head[:idx])
+		cond = strings.TrimSpace(head[idx+2:])
+	}
+
+	p, err := newParser(patSrc)
+	if err != nil {
+		return nil, "", "", err
+	}
+	pat, err := p.parsePattern()
+	if err != nil {
+		return nil, "", "", err
+	}
+	if p.cur.kind != tokEOF {
+		return nil, "", "", fmt.Errorf("match: trailing input %q after pattern", p.cur.text)
+	}
+	return pat, cond, message, nil
+}
+
+// topLevelAnd finds a "&&" that sits outside of any parentheses, so that
+// "&&" appearing inside a quoted string or nested pattern is not mistaken
+// for the pattern/condition separator.
+func topLevelAnd(s string) int {
+	depth := 0
+	inString := false
+	for i := 0; i < len(s)-1; i++ {
+		switch s[i] {
+		case '"':
+			inString = !inString
+		case '(':
+			if !inString {
+				depth++
+			}
+		case ')':
+			if !inString {
+				depth--
+			}
+		case '&':
+			if !inString && depth == 0 && s[i+1] == '&' {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,96 @@
+// Package match implements a small s-expression pattern language for
+// writing CCLint rules declaratively over go/ast, instead of writing Go.
+//
+// A rule file holds one rule per non-empty, non-"#" line:
+//
+//	pattern [&& goCondition] => message
+//
+// Patterns are parenthesized node shapes, e.g.:
+//
+//	(BinaryExpr :Op "==" :X (CallExpr :Fun (SelectorExpr :Sel "Len")) :Y lit:BasicLit) && lit.Value == `0` => avoid comparing len() to 0, use len(x) == 0 sparingly
+//
+// - A bare identifier such as `lit` binds the matched node under that name.
+// - `name:Kind` binds the matched node under `name`, additionally requiring
+// it to be a Kind (e.g. Ident, BasicLit, Expr, Stmt).
+// - `_` is a wildcard: matches anything, binds nothing.
+// - `<Kind>` is a typed hole: matches any node of the given kind, binds
+// nothing.
+// - A quoted string is a literal. It matches a BasicLit whose value
+// (unquoted, for strings) equals it, an Ident with that name, or a leaf
+// field such as BinaryExpr.Op whose text equals it (so `"0"` matches the
+// literal 0, and `"=="` matches a token.EQL operator). Other node kinds
+// are compared with their %v formatting, not their source.
+// - A trailing `...` as the last element of a field's pattern list allows
+// that list to have extra, unmatched trailing elements.
+//
+// The optional `&& goCondition` is evaluated with the pattern's bound
+// variables in scope, using Go's own operators on their rendered text;
+// see condition.go.
+package match
+
+import "go/token"
+
+// pattern is the common interface implemented by every node in a compiled
+// pattern tree.
+type pattern interface {
+	isPattern()
+}
+
+// nodePattern matches a single go/ast node of the given Kind (its Go type
+// name, e.g. "BinaryExpr", or an interface name such as "Expr" or "Stmt"),
+// plus a constraint on each of its named Fields.
+type nodePattern struct {
+	Kind   string
+	Fields []fieldPattern
+}
+
+type fieldPattern struct {
+	Name string
+	Pat  pattern
+}
+
+// listPattern matches a []ast.Node-shaped field (e.g. CallExpr.Args). If
+// Open is true the field may have extra trailing elements beyond Elems.
+type listPattern struct {
+	Elems []pattern
+	Open  bool
+}
+
+// variable binds the matched node to Name. If Kind is non-empty the node
+// must also be of that kind. Binding the same Name twice in one pattern
+// requires both occurrences to match identical source text.
+type variable struct {
+	Name string
+	Kind string
+}
+
+// wildcard matches any single node and binds nothing.
+type wildcard struct{}
+
+// typedHole matches any node of Kind and binds nothing.
+type typedHole struct {
+	Kind string
+}
+
+// literal matches a node or leaf field by its text; see literalMatches
+// and unifyField.
+type literal struct {
+	Text string
+}
+
+func (*nodePattern) isPattern() {}
+func (listPattern) isPattern()  {}
+func (variable) isPattern()     {}
+func (wildcard) isPattern()     {}
+func (typedHole) isPattern()    {}
+func (literal) isPattern()      {}
+
+// Rule is one compiled pattern => message entry, as built by LoadRules
+// from a line parsed by parseRuleLine.
+type Rule struct {
+	name      string
+	pattern   pattern
+	condition string // raw Go boolean expression snippet, or "" if absent
+	message   string
+	fset      *token.FileSet
+}
@@ -0,0 +1,116 @@
+package linter
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sync"
+)
+
+// Package represents a package in the project, and contains the list of
+// files that make it up, as well as the shared facilities (file set, type
+// info) used while linting them.
+//
+// Concurrency contract: fset is a *token.FileSet, which is itself safe for
+// concurrent use. TypesPkg, and each File's TypeInfo, are written only by
+// Check, which must not run while the package's files are being linted;
+// cli.Run calls it before linting starts, and lsp.Server calls it in
+// DidOpen before it lints. Between Checks, concurrent readers (e.g.
+// Runner's worker pool, one goroutine per file) need no locking to call
+// f.ToPosition or f.TypeOf.
+// files, on the other hand, can be mutated after construction (the LSP
+// server re-adds a file on every didChange), so it is guarded by mu.
+type Package struct {
+	fset     *token.FileSet
+	TypesPkg *types.Package
+
+	mu    sync.RWMutex
+	files map[string]*File
+}
+
+// NewPackage creates a new Package sharing the given file set.
+func NewPackage(fset *token.FileSet) *Package {
+	return &Package{
+		fset:  fset,
+		files: map[string]*File{},
+	}
+}
+
+// AddFile parses content and adds it to the package under name.
+func (p *Package) AddFile(name string, content []byte) (*File, error) {
+	f, err := NewFile(name, content, p)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	p.files[name] = f
+	p.mu.Unlock()
+	return f, nil
+}
+
+// File returns the file with the given name, if present in the package.
+func (p *Package) File(name string) (*File, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	f, ok := p.files[name]
+	return f, ok
+}
+
+// Files returns a snapshot of the files currently tracked by the package.
+func (p *Package) Files() map[string]*File {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	out := make(map[string]*File, len(p.files))
+	for name, f := range p.files {
+		out[name] = f
+	}
+	return out
+}
+
+// Check type-checks every file currently in the package under the given
+// import path, populates p.TypesPkg, and hands every one of those files a
+// shared *types.Info so rules can call File.TypeOf/ObjectOf and the
+// type-checking-aware IsUntypedConst instead of each re-deriving type
+// information on demand. It is safe to call again (e.g. after an LSP
+// didChange) to refresh the package's type information; the new Info
+// replaces the old on every file atomically from each file's point of
+// view, but Check itself is not safe to call concurrently with itself on
+// the same Package.
+//
+// Type errors are collected but not treated as fatal: Info is still
+// populated for everything the checker could resolve, which is usually
+// enough for lint rules even when the package doesn't fully compile.
+func (p *Package) Check(path string, importer types.Importer) error {
+	p.mu.RLock()
+	files := make([]*ast.File, 0, len(p.files))
+	owners := make([]*File, 0, len(p.files))
+	for _, f := range p.files {
+		files = append(files, f.AST)
+		owners = append(owners, f)
+	}
+	p.mu.RUnlock()
+
+	var firstErr error
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		Implicits:  make(map[ast.Node]types.Object),
+	}
+	conf := types.Config{
+		Importer: importer,
+		Error: func(err error) {
+			if firstErr == nil {
+				firstErr = err
+			}
+		},
+	}
+	pkg, _ := conf.Check(path, p.fset, files, info)
+
+	p.TypesPkg = pkg
+	for _, f := range owners {
+		f.TypeInfo = info
+	}
+	return firstErr
+}
@@ -0,0 +1,17 @@
+package linter
+
+// Rule defines an analysis rule that can be applied to a File.
+type Rule interface {
+	Name() string
+	Apply(*File, RuleConfig) []Failure
+}
+
+// RuleConfig is the configuration for a single rule.
+type RuleConfig struct {
+	Arguments []interface{}
+	Severity  string
+	Disabled  bool
+}
+
+// RulesConfig is a set of RuleConfig indexed by rule name.
+type RulesConfig map[string]RuleConfig
@@ -0,0 +1,153 @@
+package linter
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"time"
+)
+
+// Runner drives a lint pass over a set of files and, when Fix is set,
+// applies any Replacement edits the rules produced back to disk.
+type Runner struct {
+	Rules  []Rule
+	Config RulesConfig
+
+	// Fix, when true, makes Lint rewrite each file in place with the
+	// Replacements attached to its Failures instead of just reporting
+	// them. A file is only ever rewritten if every edit in it applies
+	// cleanly; otherwise the edits are discarded and the failures are
+	// reported as usual.
+	Fix bool
+
+	// Workers bounds how many files are linted concurrently. Zero (the
+	// default) means runtime.GOMAXPROCS(0).
+	Workers int
+
+	// Timeout, if non-zero, bounds how long a single file may spend in
+	// Rule.Apply before Lint gives up on it and reports a timeout
+	// failure instead, so one pathological rule/file pair cannot hang an
+	// entire run. Modeled on gometalinter's per-linter Deadline.
+	Timeout time.Duration
+}
+
+// Lint runs the Runner's rules over files concurrently, using a worker
+// pool sized to Workers (or GOMAXPROCS), and returns every failure across
+// every file in a stable order - by file path, then line, then column,
+// then rule name - so the output is reproducible regardless of which
+// worker happened to finish first.
+func (r *Runner) Lint(files []*File) []Failure {
+	workers := r.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(files) {
+		workers = len(files)
+	}
+	if workers == 0 {
+		return nil
+	}
+
+	jobs := make(chan *File)
+	type fileFailures struct {
+		failures []Failure
+	}
+	results := make(chan fileFailures, len(files))
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for f := range jobs {
+				failures, err := r.lintFile(f)
+				if err != nil {
+					failures = append(failures, Failure{
+						Failure:  err.Error(),
+						RuleName: "runner",
+						Position: FailurePosition{Start: f.ToPosition(f.AST.Pos())},
+					})
+				}
+				results <- fileFailures{failures: failures}
+			}
+		}()
+	}
+	go func() {
+		for _, f := range files {
+			jobs <- f
+		}
+		close(jobs)
+	}()
+
+	var all []Failure
+	for range files {
+		res := <-results
+		all = append(all, res.failures...)
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		a, b := all[i].Position.Start, all[j].Position.Start
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		if a.Column != b.Column {
+			return a.Column < b.Column
+		}
+		return all[i].RuleName < all[j].RuleName
+	})
+	return all
+}
+
+// lintFile runs LintFile, bounding it by r.Timeout when set.
+func (r *Runner) lintFile(f *File) ([]Failure, error) {
+	if r.Timeout <= 0 {
+		return r.LintFile(f)
+	}
+
+	type result struct {
+		failures []Failure
+		err      error
+	}
+	done := make(chan result, 1)
+	go func() {
+		failures, err := r.LintFile(f)
+		done <- result{failures, err}
+	}()
+
+	select {
+	case out := <-done:
+		return out.failures, out.err
+	case <-time.After(r.Timeout):
+		return nil, fmt.Errorf("%s: exceeded lint timeout of %s", f.Name, r.Timeout)
+	}
+}
+
+// LintFile runs the Runner's rules against f, optionally applying fixes,
+// and returns the failures that remain after fixing (or all of them, if
+// Fix is false or fixing failed).
+func (r *Runner) LintFile(f *File) ([]Failure, error) {
+	failures := f.Lint(r.Rules, r.Config)
+	if !r.Fix {
+		return failures, nil
+	}
+
+	fixed, err := f.ApplyFixes(failures)
+	if err != nil {
+		return failures, fmt.Errorf("%s: applying fixes: %w", f.Name, err)
+	}
+	if string(fixed) == string(f.content) {
+		return failures, nil
+	}
+	if err := os.WriteFile(f.Name, fixed, 0o644); err != nil {
+		return failures, fmt.Errorf("%s: writing fixes: %w", f.Name, err)
+	}
+
+	var remaining []Failure
+	for _, failure := range failures {
+		if len(failure.Replacement) == 0 {
+			remaining = append(remaining, failure)
+		}
+	}
+	return remaining, nil
+}
@@ -0,0 +1,80 @@
+package linter
+
+import (
+	"fmt"
+	"go/token"
+	"testing"
+	"time"
+)
+
+// orderingRule reports one failure per file at a position derived from the
+// file's name, so a test can check Runner.Lint's output order without
+// depending on which worker happened to finish first.
+type orderingRule struct{}
+
+func (orderingRule) Name() string { return "ordering" }
+
+func (orderingRule) Apply(f *File, _ RuleConfig) []Failure {
+	return []Failure{{
+		Failure:  "failure in " + f.Name,
+		Position: FailurePosition{Start: f.ToPosition(f.AST.Pos())},
+	}}
+}
+
+func TestRunnerLintIsDeterministicallyOrdered(t *testing.T) {
+	var files []*File
+	pkg := NewPackage(token.NewFileSet())
+	for i := 9; i >= 0; i-- {
+		name := fmt.Sprintf("file%d.go", i)
+		f, err := pkg.AddFile(name, []byte("package p\n"))
+		if err != nil {
+			t.Fatalf("AddFile(%s): %v", name, err)
+		}
+		files = append(files, f)
+	}
+
+	runner := &Runner{Rules: []Rule{orderingRule{}}, Workers: 4}
+	for attempt := 0; attempt < 5; attempt++ {
+		failures := runner.Lint(files)
+		if len(failures) != len(files) {
+			t.Fatalf("got %d failures, want %d", len(failures), len(files))
+		}
+		for i := 1; i < len(failures); i++ {
+			if failures[i-1].Position.Start.Filename >= failures[i].Position.Start.Filename {
+				t.Fatalf("attempt %d: failures not sorted by filename: %q then %q",
+					attempt, failures[i-1].Position.Start.Filename, failures[i].Position.Start.Filename)
+			}
+		}
+	}
+}
+
+// slowRule blocks until stop is closed, so a test can force Runner's
+// per-file timeout to fire deterministically instead of racing a sleep.
+type slowRule struct{ stop chan struct{} }
+
+func (slowRule) Name() string { return "slow" }
+
+func (r slowRule) Apply(f *File, _ RuleConfig) []Failure {
+	<-r.stop
+	return nil
+}
+
+func TestRunnerLintFileTimesOut(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+
+	pkg := NewPackage(token.NewFileSet())
+	f, err := pkg.AddFile("slow.go", []byte("package p\n"))
+	if err != nil {
+		t.Fatalf("AddFile: %v", err)
+	}
+
+	runner := &Runner{Rules: []Rule{slowRule{stop: stop}}, Timeout: 10 * time.Millisecond}
+	failures := runner.Lint([]*File{f})
+	if len(failures) != 1 {
+		t.Fatalf("got %d failures, want 1 timeout failure", len(failures))
+	}
+	if failures[0].RuleName != "runner" {
+		t.Errorf("RuleName = %q, want %q", failures[0].RuleName, "runner")
+	}
+}